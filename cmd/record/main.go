@@ -0,0 +1,51 @@
+//go:build windows
+
+// Command record is the opt-in journal recorder: it installs the keyboard and mouse hooks and
+// writes every event to an encrypted, rotating journal until interrupted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+	"github.com/ttimt/Short_Cutteer/journal"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory to write the journal files into")
+	base := flag.String("base", "shortcutteer.journal", "journal file base name (rotated as base.0, base.1, ...)")
+	passphrase := flag.String("passphrase", "", "passphrase to encrypt the journal with (required)")
+	redact := flag.Bool("redact", false, "mask keystrokes typed into windows whose title looks like a password field "+
+		"(best-effort only - most real password fields won't be caught; see journal.passwordTitleHeuristic)")
+	flag.Parse()
+
+	if *passphrase == "" {
+		fmt.Fprintln(os.Stderr, "usage: record -passphrase <passphrase> [-dir .] [-base shortcutteer.journal] [-redact=false]")
+		os.Exit(2)
+	}
+
+	win.LoadDLLs()
+
+	writer, err := journal.NewWriter(*dir, *base, *passphrase, *redact)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "record:", err)
+		os.Exit(1)
+	}
+	defer writer.Close()
+
+	keyEvents := win.InstallKeyboardHook()
+	defer win.UninstallKeyboardHook()
+
+	mouseEvents := win.InstallMouseHook()
+	defer win.UninstallMouseHook()
+
+	go journal.RecordKeyboard(writer, keyEvents)
+	go journal.RecordMouse(writer, mouseEvents)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+}
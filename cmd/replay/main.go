@@ -0,0 +1,83 @@
+//go:build windows
+
+// Command replay reads a journal written by cmd/record and re-injects its keyboard events via
+// SendInput at a configurable speed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+	"unsafe"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+	"github.com/ttimt/Short_Cutteer/journal"
+)
+
+func main() {
+	journalPath := flag.String("journal", "", "path to a single journal file written by cmd/record (e.g. shortcutteer.journal.0)")
+	passphrase := flag.String("passphrase", "", "passphrase the journal was encrypted with (required)")
+	speed := flag.Float64("speed", 1, "replay speed multiplier (2 = twice as fast, 0.5 = half speed)")
+	flag.Parse()
+
+	if *journalPath == "" || *passphrase == "" {
+		fmt.Fprintln(os.Stderr, "usage: replay -journal <path> -passphrase <passphrase> [-speed 1]")
+		os.Exit(2)
+	}
+
+	win.LoadDLLs()
+
+	if err := replay(*journalPath, *passphrase, *speed); err != nil {
+		fmt.Fprintln(os.Stderr, "replay:", err)
+		os.Exit(1)
+	}
+}
+
+// replay reads journalPath's Records in order, sleeping between them (scaled by speed) to
+// reproduce the original typing cadence, and re-injects every non-redacted keyboard event
+func replay(journalPath string, passphrase string, speed float64) error {
+	reader, err := journal.NewReader(journalPath, passphrase)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var lastTime int64
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if lastTime != 0 && speed > 0 {
+			if gap := time.Duration(float64(record.Time-lastTime)/speed) * time.Millisecond; gap > 0 {
+				time.Sleep(gap)
+			}
+		}
+		lastTime = record.Time
+
+		if record.Kind != journal.EventKeyboard || record.Redacted {
+			continue
+		}
+
+		sendKey(record)
+	}
+}
+
+// sendKey re-injects a single journaled keyboard Record via SendInput
+func sendKey(record journal.Record) {
+	input := win.TagINPUT{InputType: win.INPUT_KEYBOARD}
+	input.Ki.WVk = uint16(record.VkCode)
+	input.Ki.WScan = uint16(record.ScanCode)
+	input.Ki.DwFlags = win.LLKHFToSendInputFlags(win.DWORD(record.Flags))
+	if record.KeyUp {
+		input.Ki.DwFlags |= win.KEYEVENTF_KEYUP
+	}
+
+	win.SendInput(1, win.LPINPUT(input), int(unsafe.Sizeof(input)))
+}
@@ -0,0 +1,55 @@
+//go:build windows
+
+// Command shortcut loads a shortcuts.yaml/shortcuts.json config, installs the keyboard hook, and
+// runs until interrupted, firing the configured actions whenever a trigger matches and reloading
+// the config whenever the file changes on disk.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+	"github.com/ttimt/Short_Cutteer/shortcut"
+)
+
+func main() {
+	configPath := flag.String("config", "shortcuts.yaml", "path to a shortcuts.yaml/shortcuts.json config file")
+	flag.Parse()
+
+	win.LoadDLLs()
+
+	config, err := shortcut.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "shortcut:", err)
+		os.Exit(1)
+	}
+
+	engine := shortcut.NewEngine()
+	if err := engine.Load(config); err != nil {
+		fmt.Fprintln(os.Stderr, "shortcut:", err)
+		os.Exit(1)
+	}
+
+	keyEvents := win.InstallKeyboardHook()
+	defer win.UninstallKeyboardHook()
+	go func() {
+		for range keyEvents {
+			// Drain: matched triggers are consumed and swallowed inside the hook itself: this
+			// only keeps the channel from blocking the hook chain for whatever falls through.
+		}
+	}()
+
+	watcher, err := shortcut.WatchConfig(*configPath, engine)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "shortcut:", err)
+		os.Exit(1)
+	}
+	defer watcher.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+}
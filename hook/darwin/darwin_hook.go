@@ -0,0 +1,151 @@
+//go:build darwin
+
+// Package darwin implements hook.Hooker on macOS via a CGEventTap for capture and
+// CGEventCreateKeyboardEvent/CGEventPost for synthesis. Capturing system-wide key events
+// requires the process to have Accessibility (or Input Monitoring) permission.
+package darwin
+
+/*
+#cgo LDFLAGS: -framework ApplicationServices
+
+#include <ApplicationServices/ApplicationServices.h>
+
+extern void goKeyEventCallback(CGKeyCode keyCode, bool keyUp, uint64_t timestamp);
+
+static CGEventRef tapCallback(CGEventTapProxy proxy, CGEventType type, CGEventRef event, void *refcon) {
+	if (type == kCGEventKeyDown || type == kCGEventKeyUp) {
+		CGKeyCode keyCode = (CGKeyCode)CGEventGetIntegerValueField(event, kCGKeyboardEventKeycode);
+		goKeyEventCallback(keyCode, type == kCGEventKeyUp, CGEventGetTimestamp(event));
+	}
+	return event;
+}
+
+static CFMachPortRef installTap() {
+	CGEventMask mask = CGEventMaskBit(kCGEventKeyDown) | CGEventMaskBit(kCGEventKeyUp);
+	return CGEventTapCreate(kCGSessionEventTap, kCGHeadInsertEventTap, kCGEventTapOptionDefault, mask, tapCallback, NULL);
+}
+
+static void runTap(CFMachPortRef tap) {
+	CFRunLoopSourceRef source = CFMachPortCreateRunLoopSource(kCFAllocatorDefault, tap, 0);
+	CFRunLoopAddSource(CFRunLoopGetCurrent(), source, kCFRunLoopCommonModes);
+	CGEventTapEnable(tap, true);
+	CFRunLoopRun();
+}
+
+static void postKeyEvent(CGKeyCode keyCode, bool keyUp) {
+	CGEventRef event = CGEventCreateKeyboardEvent(NULL, keyCode, !keyUp);
+	CGEventPost(kCGHIDEventTap, event);
+	CFRelease(event);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+
+	"github.com/ttimt/Short_Cutteer/hook"
+)
+
+// keyToCode maps the portable hook.Key enum to macOS virtual key codes (Carbon/CGKeyCode values)
+var keyToCode = map[hook.Key]C.CGKeyCode{
+	hook.KeyA: 0, hook.KeyS: 1, hook.KeyD: 2, hook.KeyF: 3, hook.KeyH: 4,
+	hook.KeyG: 5, hook.KeyZ: 6, hook.KeyX: 7, hook.KeyC: 8, hook.KeyV: 9,
+	hook.KeyB: 11, hook.KeyQ: 12, hook.KeyW: 13, hook.KeyE: 14, hook.KeyR: 15,
+	hook.KeyY: 16, hook.KeyT: 17, hook.KeyO: 31, hook.KeyU: 32, hook.KeyI: 34,
+	hook.KeyP: 35, hook.KeyL: 37, hook.KeyJ: 38, hook.KeyK: 40, hook.KeyN: 45, hook.KeyM: 46,
+
+	hook.Key1: 18, hook.Key2: 19, hook.Key3: 20, hook.Key4: 21, hook.Key5: 23,
+	hook.Key6: 22, hook.Key7: 26, hook.Key8: 28, hook.Key9: 25, hook.Key0: 29,
+
+	hook.KeyEnter: 36, hook.KeyTab: 48, hook.KeySpace: 49, hook.KeyBackspace: 51,
+	hook.KeyEscape: 53, hook.KeyLeftSuper: 55, hook.KeyShift: 56, hook.KeyCapsLock: 57, hook.KeyAlt: 58, hook.KeyControl: 59,
+
+	hook.KeyLeftArrow: 123, hook.KeyRightArrow: 124, hook.KeyDownArrow: 125, hook.KeyUpArrow: 126,
+	hook.KeyHome: 115, hook.KeyPageUp: 116, hook.KeyDelete: 117, hook.KeyEnd: 119, hook.KeyPageDown: 121, hook.KeyInsert: 114,
+
+	hook.KeyF1: 122, hook.KeyF2: 120, hook.KeyF3: 99, hook.KeyF4: 118, hook.KeyF5: 96, hook.KeyF6: 97,
+	hook.KeyF7: 98, hook.KeyF8: 100, hook.KeyF9: 101, hook.KeyF10: 109, hook.KeyF11: 103, hook.KeyF12: 111,
+}
+
+// codeToKey is the reverse of keyToCode, built once at init
+var codeToKey = map[C.CGKeyCode]hook.Key{}
+
+func init() {
+	for key, code := range keyToCode {
+		codeToKey[code] = key
+	}
+}
+
+// events is the shared event channel, written to from the cgo callback which has no way to
+// carry a Go receiver pointer through CGEventTapCreate's C function pointer
+var events chan hook.KeyEvent
+
+//export goKeyEventCallback
+func goKeyEventCallback(keyCode C.CGKeyCode, keyUp C.bool, timestamp C.uint64_t) {
+	if events == nil {
+		return
+	}
+
+	key, ok := codeToKey[keyCode]
+	if !ok {
+		return
+	}
+
+	select {
+	case events <- hook.KeyEvent{Key: key, KeyUp: bool(keyUp), Time: uint32(timestamp / 1e6)}:
+	default:
+	}
+}
+
+// Backend implements hook.Hooker via a CGEventTap
+type Backend struct {
+	tap C.CFMachPortRef
+}
+
+// New creates a Backend; call Install to start capturing
+func New() *Backend {
+	return &Backend{}
+}
+
+// Install creates and enables a CGEventTap on its own run loop goroutine
+func (b *Backend) Install() error {
+	tap := C.installTap()
+	if tap == nil {
+		return fmt.Errorf("hook/darwin: CGEventTapCreate failed - grant Accessibility/Input Monitoring permission")
+	}
+
+	b.tap = tap
+	events = make(chan hook.KeyEvent, 64)
+
+	go C.runTap(tap)
+
+	return nil
+}
+
+// Uninstall disables the CGEventTap and closes the event channel
+func (b *Backend) Uninstall() error {
+	C.CGEventTapEnable(b.tap, C.bool(false))
+	close(events)
+	events = nil
+
+	return nil
+}
+
+// Events returns the channel of captured KeyEvent
+func (b *Backend) Events() <-chan hook.KeyEvent {
+	return events
+}
+
+// SendInput synthesizes the given key events via CGEventCreateKeyboardEvent/CGEventPost
+func (b *Backend) SendInput(keyEvents []hook.KeyEvent) error {
+	for _, event := range keyEvents {
+		code, ok := keyToCode[event.Key]
+		if !ok {
+			return fmt.Errorf("hook/darwin: no CGKeyCode mapping for key %v", event.Key)
+		}
+
+		C.postKeyEvent(code, C.bool(event.KeyUp))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+// Package hook defines a portable low-level input hook interface, pluggable per OS behind a
+// build tag: hook/windows (WH_KEYBOARD_LL/WH_MOUSE_LL), hook/linux (evdev/uinput), and
+// hook/darwin (CGEventTap). It's the target abstraction for making input capture/synthesis
+// cross-platform; the shortcut package does not use it yet (see shortcut's package doc) since
+// its sequence matcher needs in-hook swallow/replay semantics this interface doesn't expose.
+package hook
+
+// KeyEvent is a portable keyboard event, translated from whichever native hook captured it
+type KeyEvent struct {
+	Key   Key
+	KeyUp bool
+	Time  uint32
+}
+
+// Hooker installs a low-level, system-wide keyboard hook and can synthesize key events back to
+// the operating system. Each OS backend (hook/windows, hook/linux, hook/darwin) implements it.
+type Hooker interface {
+	// Install starts capturing system-wide key events
+	Install() error
+
+	// Uninstall stops capturing and releases any OS resources Install acquired
+	Uninstall() error
+
+	// Events returns the channel of captured KeyEvent; closed once Uninstall returns
+	Events() <-chan KeyEvent
+
+	// SendInput synthesizes the given key events back to the operating system, in order
+	SendInput(events []KeyEvent) error
+}
+
+// Key is a portable keycode, mapped by each backend to its native representation (Win32 VK_*,
+// Linux KEY_*, or a macOS CGKeyCode)
+type Key int
+
+const (
+	KeyUnknown Key = iota
+
+	KeyBackspace
+	KeyTab
+	KeyEnter
+	KeyShift
+	KeyControl
+	KeyAlt
+	KeyCapsLock
+	KeyEscape
+	KeySpace
+	KeyPageUp
+	KeyPageDown
+	KeyEnd
+	KeyHome
+	KeyLeftArrow
+	KeyUpArrow
+	KeyRightArrow
+	KeyDownArrow
+	KeyInsert
+	KeyDelete
+	KeyLeftSuper // Windows key / Command key
+
+	Key0
+	Key1
+	Key2
+	Key3
+	Key4
+	Key5
+	Key6
+	Key7
+	Key8
+	Key9
+
+	KeyA
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
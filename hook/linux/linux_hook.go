@@ -0,0 +1,290 @@
+//go:build linux
+
+// Package linux implements hook.Hooker on Linux via evdev (capture) and uinput (synthesis).
+// Reading the capture device and creating the uinput device both require the process to be in
+// the "input" group (or run as root).
+package linux
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/ttimt/Short_Cutteer/hook"
+)
+
+const (
+	evSyn = 0x00
+	evKey = 0x01
+
+	synReport = 0
+
+	uiSetEvBit   = 0x40045564
+	uiSetKeyBit  = 0x40045565
+	uiDevSetup   = 0x405c5503
+	uiDevCreate  = 0x5501
+	uiDevDestroy = 0x5502
+)
+
+// inputEvent mirrors struct input_event from linux/input.h on 64-bit platforms
+type inputEvent struct {
+	Sec   int64
+	Usec  int64
+	Type  uint16
+	Code  uint16
+	Value int32
+}
+
+// inputID mirrors struct input_id from linux/input.h
+type inputID struct {
+	BusType uint16
+	Vendor  uint16
+	Product uint16
+	Version uint16
+}
+
+// uinputSetup mirrors struct uinput_setup from linux/uinput.h, used with the UI_DEV_SETUP ioctl
+type uinputSetup struct {
+	ID           inputID
+	Name         [80]byte
+	FFEffectsMax uint32
+}
+
+// keyToCode maps the portable hook.Key enum to Linux KEY_* codes (linux/input-event-codes.h)
+var keyToCode = map[hook.Key]uint16{
+	hook.KeyA: 30, hook.KeyB: 48, hook.KeyC: 46, hook.KeyD: 32, hook.KeyE: 18,
+	hook.KeyF: 33, hook.KeyG: 34, hook.KeyH: 35, hook.KeyI: 23, hook.KeyJ: 36,
+	hook.KeyK: 37, hook.KeyL: 38, hook.KeyM: 50, hook.KeyN: 49, hook.KeyO: 24,
+	hook.KeyP: 25, hook.KeyQ: 16, hook.KeyR: 19, hook.KeyS: 31, hook.KeyT: 20,
+	hook.KeyU: 22, hook.KeyV: 47, hook.KeyW: 17, hook.KeyX: 45, hook.KeyY: 21, hook.KeyZ: 44,
+
+	hook.Key0: 11, hook.Key1: 2, hook.Key2: 3, hook.Key3: 4, hook.Key4: 5,
+	hook.Key5: 6, hook.Key6: 7, hook.Key7: 8, hook.Key8: 9, hook.Key9: 10,
+
+	hook.KeyBackspace: 14, hook.KeyTab: 15, hook.KeyEnter: 28, hook.KeyShift: 42,
+	hook.KeyControl:   29, hook.KeyAlt: 56, hook.KeyCapsLock: 58, hook.KeyEscape: 1, hook.KeySpace: 57,
+
+	hook.KeyPageUp: 104, hook.KeyPageDown: 109, hook.KeyEnd: 107, hook.KeyHome: 102,
+	hook.KeyLeftArrow: 105, hook.KeyUpArrow: 103, hook.KeyRightArrow: 106, hook.KeyDownArrow: 108,
+	hook.KeyInsert: 110, hook.KeyDelete: 111, hook.KeyLeftSuper: 125,
+
+	hook.KeyF1: 59, hook.KeyF2: 60, hook.KeyF3: 61, hook.KeyF4: 62, hook.KeyF5: 63, hook.KeyF6: 64,
+	hook.KeyF7: 65, hook.KeyF8: 66, hook.KeyF9: 67, hook.KeyF10: 68, hook.KeyF11: 87, hook.KeyF12: 88,
+}
+
+// codeToKey is the reverse of keyToCode, built once at init
+var codeToKey = map[uint16]hook.Key{}
+
+func init() {
+	for key, code := range keyToCode {
+		codeToKey[code] = key
+	}
+}
+
+// Backend implements hook.Hooker via an evdev capture device and a uinput synthesis device
+type Backend struct {
+	capture *os.File
+	inject  *os.File
+	events  chan hook.KeyEvent
+}
+
+// New creates a Backend; call Install to open the devices and start capturing
+func New() *Backend {
+	return &Backend{}
+}
+
+// Install opens the first evdev device that reports EV_KEY for capture, and creates a uinput
+// device for synthesis
+func (b *Backend) Install() error {
+	capturePath, err := findKeyboardDevice()
+	if err != nil {
+		return err
+	}
+
+	capture, err := os.OpenFile(capturePath, os.O_RDONLY, 0)
+	if err != nil {
+		return fmt.Errorf("hook/linux: open %s: %w", capturePath, err)
+	}
+	b.capture = capture
+
+	inject, err := createUinputDevice()
+	if err != nil {
+		capture.Close()
+		return err
+	}
+	b.inject = inject
+
+	b.events = make(chan hook.KeyEvent, 64)
+	go b.readLoop()
+
+	return nil
+}
+
+// Uninstall closes the capture and uinput devices and closes the event channel
+func (b *Backend) Uninstall() error {
+	if b.inject != nil {
+		unix.IoctlSetInt(int(b.inject.Fd()), uiDevDestroy, 0)
+		b.inject.Close()
+	}
+
+	if b.capture != nil {
+		return b.capture.Close()
+	}
+
+	return nil
+}
+
+// Events returns the channel of captured KeyEvent
+func (b *Backend) Events() <-chan hook.KeyEvent {
+	return b.events
+}
+
+// SendInput synthesizes the given key events by writing input_event records to the uinput device
+func (b *Backend) SendInput(events []hook.KeyEvent) error {
+	for _, event := range events {
+		code, ok := keyToCode[event.Key]
+		if !ok {
+			return fmt.Errorf("hook/linux: no KEY_* mapping for key %v", event.Key)
+		}
+
+		value := int32(1)
+		if event.KeyUp {
+			value = 0
+		}
+
+		if err := writeInputEvent(b.inject, evKey, code, value); err != nil {
+			return err
+		}
+		if err := writeInputEvent(b.inject, evSyn, synReport, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readLoop reads raw input_event records off the capture device and translates EV_KEY events
+// into portable KeyEvent values until the device is closed
+func (b *Backend) readLoop() {
+	defer close(b.events)
+
+	buf := make([]byte, binary.Size(inputEvent{}))
+	for {
+		if _, err := b.capture.Read(buf); err != nil {
+			return
+		}
+
+		var raw inputEvent
+		if err := binary.Read(bytes.NewReader(buf), binary.LittleEndian, &raw); err != nil {
+			continue
+		}
+
+		if raw.Type != evKey {
+			continue
+		}
+
+		key, ok := codeToKey[raw.Code]
+		if !ok {
+			continue
+		}
+
+		b.events <- hook.KeyEvent{Key: key, KeyUp: raw.Value == 0, Time: uint32(raw.Sec*1000 + raw.Usec/1000)}
+	}
+}
+
+// writeInputEvent writes a single input_event record to f
+func writeInputEvent(f *os.File, evType uint16, code uint16, value int32) error {
+	buf := &bytes.Buffer{}
+	event := inputEvent{Type: evType, Code: code, Value: value}
+	if err := binary.Write(buf, binary.LittleEndian, event); err != nil {
+		return err
+	}
+
+	_, err := f.Write(buf.Bytes())
+	return err
+}
+
+// findKeyboardDevice returns the first /dev/input/event* device that advertises EV_KEY support
+func findKeyboardDevice() (string, error) {
+	matches, err := filepath.Glob("/dev/input/event*")
+	if err != nil {
+		return "", err
+	}
+
+	for _, path := range matches {
+		f, err := os.OpenFile(path, os.O_RDONLY, 0)
+		if err != nil {
+			continue
+		}
+
+		var evBits [4]byte
+		_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), uintptr(eviocgbit(0, len(evBits))), uintptr(unsafe.Pointer(&evBits)))
+		f.Close()
+		if errno != 0 {
+			continue
+		}
+
+		if evBits[0]&(1<<evKey) != 0 {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("hook/linux: no EV_KEY-capable device found under /dev/input")
+}
+
+// eviocgbit computes the EVIOCGBIT(ev, len) ioctl request number
+func eviocgbit(ev int, length int) uint {
+	const iocRead = 2
+	return uint(iocRead<<30) | uint(length&0x3fff)<<16 | 'E'<<8 | (0x20 + uint(ev))
+}
+
+// createUinputDevice opens /dev/uinput, registers EV_KEY plus every mapped key code, and creates
+// the virtual device
+func createUinputDevice() (*os.File, error) {
+	f, err := os.OpenFile("/dev/uinput", os.O_WRONLY|unix.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, fmt.Errorf("hook/linux: open /dev/uinput: %w", err)
+	}
+
+	if err := unix.IoctlSetInt(int(f.Fd()), uiSetEvBit, evKey); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("hook/linux: UI_SET_EVBIT: %w", err)
+	}
+
+	for _, code := range keyToCode {
+		if err := unix.IoctlSetInt(int(f.Fd()), uiSetKeyBit, int(code)); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("hook/linux: UI_SET_KEYBIT %d: %w", code, err)
+		}
+	}
+
+	setup := uinputSetup{ID: inputID{BusType: 0x03, Vendor: 0x1, Product: 0x1, Version: 1}}
+	copy(setup.Name[:], "short-cutteer-virtual-keyboard")
+
+	if err := ioctlSetup(f, &setup); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("hook/linux: UI_DEV_SETUP: %w", err)
+	}
+
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), uintptr(uiDevCreate), 0); errno != 0 {
+		f.Close()
+		return nil, fmt.Errorf("hook/linux: UI_DEV_CREATE: %w", errno)
+	}
+
+	return f, nil
+}
+
+// ioctlSetup issues the UI_DEV_SETUP ioctl, which takes a uinput_setup struct by pointer
+func ioctlSetup(f *os.File, setup *uinputSetup) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), uintptr(uiDevSetup), uintptr(unsafe.Pointer(setup)))
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
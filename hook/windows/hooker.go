@@ -0,0 +1,129 @@
+//go:build windows
+
+package windows
+
+import (
+	"fmt"
+
+	"github.com/ttimt/Short_Cutteer/hook"
+)
+
+// keyToVK maps the portable hook.Key enum to Win32 VK_* codes
+var keyToVK = map[hook.Key]uint16{
+	hook.KeyBackspace:  VK_BACK,
+	hook.KeyTab:        VK_TAB,
+	hook.KeyEnter:      VK_RETURN,
+	hook.KeyShift:      VK_SHIFT,
+	hook.KeyControl:    VK_CONTROL,
+	hook.KeyAlt:        VK_MENU,
+	hook.KeyCapsLock:   VK_CAPITAL,
+	hook.KeyEscape:     VK_ESCAPE,
+	hook.KeySpace:      VK_SPACE,
+	hook.KeyPageUp:     VK_PRIOR,
+	hook.KeyPageDown:   VK_NEXT,
+	hook.KeyEnd:        VK_END,
+	hook.KeyHome:       VK_HOME,
+	hook.KeyLeftArrow:  VK_LEFT,
+	hook.KeyUpArrow:    VK_UP,
+	hook.KeyRightArrow: VK_RIGHT,
+	hook.KeyDownArrow:  VK_DOWN,
+	hook.KeyInsert:     VK_INSERT,
+	hook.KeyDelete:     VK_DELETE,
+	hook.KeyLeftSuper:  VK_LWIN,
+	hook.KeyF1:         VK_F1,
+	hook.KeyF2:         VK_F2,
+	hook.KeyF3:         VK_F3,
+	hook.KeyF4:         VK_F4,
+	hook.KeyF5:         VK_F5,
+	hook.KeyF6:         VK_F6,
+	hook.KeyF7:         VK_F7,
+	hook.KeyF8:         VK_F8,
+	hook.KeyF9:         VK_F9,
+	hook.KeyF10:        VK_F10,
+	hook.KeyF11:        VK_F11,
+	hook.KeyF12:        VK_F12,
+}
+
+// vkToKey is the reverse of keyToVK, built once at init
+var vkToKey = map[uint16]hook.Key{}
+
+func init() {
+	for c := 0; c < 10; c++ {
+		keyToVK[hook.Key0+hook.Key(c)] = uint16('0' + c)
+	}
+	for c := 0; c < 26; c++ {
+		keyToVK[hook.KeyA+hook.Key(c)] = uint16('A' + c)
+	}
+
+	for key, vk := range keyToVK {
+		vkToKey[vk] = key
+	}
+}
+
+// Backend implements hook.Hooker over the Win32 WH_KEYBOARD_LL hook and SendInput
+type Backend struct {
+	events chan hook.KeyEvent
+}
+
+// New creates a Backend; call Install to start capturing
+func New() *Backend {
+	return &Backend{}
+}
+
+// Install starts capturing system-wide key events via WH_KEYBOARD_LL
+func (b *Backend) Install() error {
+	LoadDLLs()
+
+	raw := InstallKeyboardHook()
+	b.events = make(chan hook.KeyEvent, 64)
+
+	go func() {
+		for event := range raw {
+			b.events <- hook.KeyEvent{
+				Key:   vkToKey[uint16(event.VkCode)],
+				KeyUp: event.KeyUp,
+				Time:  uint32(event.Time),
+			}
+		}
+		close(b.events)
+	}()
+
+	return nil
+}
+
+// Uninstall removes the WH_KEYBOARD_LL hook
+func (b *Backend) Uninstall() error {
+	UninstallKeyboardHook()
+
+	return nil
+}
+
+// Events returns the channel of captured KeyEvent
+func (b *Backend) Events() <-chan hook.KeyEvent {
+	return b.events
+}
+
+// SendInput synthesizes the given key events via a single batched Win32 SendInput call, so a
+// caller sending a whole combo at once gets atomic delivery
+func (b *Backend) SendInput(events []hook.KeyEvent) error {
+	inputs := make([]TagINPUT, 0, len(events))
+
+	for _, event := range events {
+		vk, ok := keyToVK[event.Key]
+		if !ok {
+			return fmt.Errorf("hook/windows: no VK mapping for key %v", event.Key)
+		}
+
+		input := TagINPUT{InputType: INPUT_KEYBOARD}
+		input.Ki.WVk = vk
+		if event.KeyUp {
+			input.Ki.DwFlags = KEYEVENTF_KEYUP
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	SendInputs(inputs)
+
+	return nil
+}
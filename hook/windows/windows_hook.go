@@ -1,4 +1,8 @@
-package main
+//go:build windows
+
+// Package windows wraps the Win32 low-level hook APIs (WH_KEYBOARD_LL, WH_MOUSE_LL)
+// and the SendInput synthesis APIs used to drive shortcuts on Windows.
+package windows
 
 import (
 	"log"
@@ -18,12 +22,12 @@ type (
 	HWND      uintptr
 	LPMSG     uintptr
 	WPARAM    uintptr
-	LPINPUT   tagINPUT
+	LPINPUT   TagINPUT
 
 	// Callback function after SendMessage function is called (Keyboard input received)
 	// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nc-winuser-hookproc
 	//
-	// LPARAM is a pointer to a KBDLLHOOKSTRUCT struct :
+	// LPARAM is a pointer to a KBDLLHOOKSTRUCT or MSLLHOOKSTRUCT struct depending on the hook type:
 	// https://docs.microsoft.com/en-us/previous-versions/windows/desktop/legacy/ms644985(v=vs.85)
 	HOOKPROC func(int, WPARAM, LPARAM) LRESULT
 )
@@ -40,9 +44,9 @@ type tagKBDLLHOOKSTRUCT struct {
 
 // Input events
 // https://docs.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-input
-type tagINPUT struct {
-	inputType uint32
-	ki        KEYBDINPUT
+type TagINPUT struct {
+	InputType uint32
+	Ki        KEYBDINPUT
 	padding   uint64
 }
 
@@ -81,6 +85,25 @@ const (
 	VK_OEM_4   = 0xDB // '[{' key
 	VK_OEM_6   = 0xDD // ']}' key
 	VK_OEM_7   = 0xDE // 'single-quote/double-quote' key
+	VK_ESCAPE  = 0x1B
+	VK_PRIOR   = 0x21 // Page up key
+	VK_NEXT    = 0x22 // Page down key
+	VK_INSERT  = 0x2D
+	VK_DELETE  = 0x2E
+	VK_LWIN    = 0x5B
+	VK_RWIN    = 0x5C
+	VK_F1      = 0x70
+	VK_F2      = 0x71
+	VK_F3      = 0x72
+	VK_F4      = 0x73
+	VK_F5      = 0x74
+	VK_F6      = 0x75
+	VK_F7      = 0x76
+	VK_F8      = 0x77
+	VK_F9      = 0x78
+	VK_F10     = 0x79
+	VK_F11     = 0x7A
+	VK_F12     = 0x7B
 
 	// Types of input event:
 	// https://docs.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-input#members
@@ -94,6 +117,15 @@ const (
 	KEYEVENTF_KEYUP       = 0x0002
 	KEYEVENTF_SCANCODE    = 0x0008
 	KEYEVENTF_UNICODE     = 0x0004
+
+	// KBDLLHOOKSTRUCT.flags bits, a distinct bit layout from the KEYEVENTF_* flags above despite
+	// some numeric overlap (e.g. both define bit 0x02): do not pass one where the other is expected
+	// https://docs.microsoft.com/en-us/previous-versions/windows/desktop/legacy/ms644985(v=vs.85)
+	LLKHF_EXTENDED          = 0x01
+	LLKHF_LOWER_IL_INJECTED = 0x02
+	LLKHF_INJECTED          = 0x10
+	LLKHF_ALTDOWN           = 0x20
+	LLKHF_UP                = 0x80
 )
 
 var (
@@ -117,7 +149,7 @@ func LoadDLLs() {
 	}
 }
 
-// Pass the hook information to the next hook procedure
+// CallNextHookEx passes the hook information to the next hook procedure
 // A hook procedure can call this function either before or after processing the hook information
 // https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-callnexthookex
 func CallNextHookEx(hhk HHOOK, nCode int, wParam WPARAM, lParam LPARAM) LRESULT {
@@ -126,7 +158,7 @@ func CallNextHookEx(hhk HHOOK, nCode int, wParam WPARAM, lParam LPARAM) LRESULT
 	return LRESULT(result)
 }
 
-// Install hook procedure into a hhook chain
+// SetWindowsHookExW installs a hook procedure into a hhook chain
 // https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-setwindowshookexw
 func SetWindowsHookExW(idHook int, lpfn HOOKPROC, hmod HINSTANCE, dwThreadID DWORD) HHOOK {
 	result, _, _ := winDLLUser32_ProcSetWindowsHookExW.Call(uintptr(idHook), windows.NewCallback(lpfn), uintptr(hmod), uintptr(dwThreadID))
@@ -134,7 +166,7 @@ func SetWindowsHookExW(idHook int, lpfn HOOKPROC, hmod HINSTANCE, dwThreadID DWO
 	return HHOOK(result)
 }
 
-// Remove a hook procedure installed in a hook chain by the SetWindowsHookEx function
+// UnhookWindowsHookEx removes a hook procedure installed in a hook chain by the SetWindowsHookEx function
 // https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-unhookwindowshookex
 func UnhookWindowsHookEx(hhk HHOOK) bool {
 	result, _, err := winDLLUser32_ProcUnhookWindowsHookEx.Call(uintptr(hhk))
@@ -147,7 +179,7 @@ func UnhookWindowsHookEx(hhk HHOOK) bool {
 	return true
 }
 
-// Retrieves a message
+// GetMessageW retrieves a message
 // https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getmessagew
 func GetMessageW(lpMsg LPMSG, hWnd HWND, wMsgFilterMin uint, wMsgFilterMax uint) bool {
 	res, _, err := winDLLUser32_GetMessageW.Call(uintptr(lpMsg), uintptr(hWnd), uintptr(wMsgFilterMin), uintptr(wMsgFilterMax))
@@ -160,11 +192,16 @@ func GetMessageW(lpMsg LPMSG, hWnd HWND, wMsgFilterMin uint, wMsgFilterMax uint)
 	return true
 }
 
-// Simulate keyboard inputs to the operating system
+// SendInputs simulates a batch of keyboard inputs to the operating system in a single syscall,
+// so e.g. a Shift+Key or Ctrl+K,Ctrl+D combo's events can't be interleaved with real user input
+// the way sending them one SendInput call at a time can.
 // https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-sendinput
-func SendInput(cInputs uint, pInputs LPINPUT, cbSize int) uint {
+func SendInputs(events []TagINPUT) uint {
+	if len(events) == 0 {
+		return 0
+	}
 
-	result, _, err := winDLLUser32_SendInput.Call(uintptr(cInputs), uintptr(unsafe.Pointer(&pInputs)), uintptr(cbSize))
+	result, _, err := winDLLUser32_SendInput.Call(uintptr(len(events)), uintptr(unsafe.Pointer(&events[0])), unsafe.Sizeof(TagINPUT{}))
 
 	if result == 0 {
 		log.Println("SendInput error:", err)
@@ -174,7 +211,14 @@ func SendInput(cInputs uint, pInputs LPINPUT, cbSize int) uint {
 	return uint(result)
 }
 
-// Retrieves the status of the specified virtual key
+// SendInput simulates a single keyboard input to the operating system. It's a thin wrapper
+// around SendInputs kept for compatibility with existing single-event call sites; prefer
+// SendInputs when sending more than one event so they're delivered atomically.
+func SendInput(cInputs uint, pInputs LPINPUT, cbSize int) uint {
+	return SendInputs([]TagINPUT{TagINPUT(pInputs)})
+}
+
+// GetKeyState retrieves the status of the specified virtual key
 // The status specifies whether the key is up, down or toggled (on, off - alternating each time the key is pressed)
 //
 // Returned bits = 16 bits
@@ -185,4 +229,19 @@ func GetKeyState(nVirtKey int) SHORT {
 	result, _, _ := winDLLUser32_GetKeyState.Call(uintptr(nVirtKey))
 
 	return SHORT(result)
-}
\ No newline at end of file
+}
+
+// LLKHFToSendInputFlags translates a captured KBDLLHOOKSTRUCT.flags value into the equivalent
+// KEYEVENTF_* flags for SendInput. The two bit layouts are unrelated and must not be assigned to
+// one another directly (e.g. both define bit 0x02, for LLKHF_LOWER_IL_INJECTED and
+// KEYEVENTF_KEYUP respectively); only LLKHF_EXTENDED has a SendInput equivalent worth preserving,
+// KEYEVENTF_KEYUP is set separately by the caller from the event's up/down state.
+func LLKHFToSendInputFlags(llFlags DWORD) uint32 {
+	var flags uint32
+
+	if llFlags&LLKHF_EXTENDED != 0 {
+		flags |= KEYEVENTF_EXTENDEDKEY
+	}
+
+	return flags
+}
@@ -0,0 +1,90 @@
+//go:build windows
+
+package windows
+
+import (
+	"unsafe"
+)
+
+// KeyEvent is emitted on the channel returned by InstallKeyboardHook for every
+// WM_KEYDOWN/WM_KEYUP captured by the hook
+type KeyEvent struct {
+	VkCode   DWORD
+	ScanCode DWORD
+	Flags    DWORD
+	Time     DWORD
+	KeyUp    bool
+}
+
+const (
+	// Keyboard messages, passed as wParam to the WH_KEYBOARD_LL HOOKPROC:
+	// https://docs.microsoft.com/en-us/windows/win32/inputdev/keyboard-input-notifications
+	WM_KEYDOWN    = 0x0100
+	WM_KEYUP      = 0x0101
+	WM_SYSKEYDOWN = 0x0104
+	WM_SYSKEYUP   = 0x0105
+)
+
+var (
+	keyboardHookHandle HHOOK
+	keyboardEventChan  chan KeyEvent
+)
+
+// InstallKeyboardHook installs a WH_KEYBOARD_LL hook and returns a channel of KeyEvent.
+// Call UninstallKeyboardHook to remove the hook and close the channel.
+func InstallKeyboardHook() <-chan KeyEvent {
+	keyboardEventChan = make(chan KeyEvent, 64)
+	keyboardHookHandle = SetWindowsHookExW(WH_KEYBOARD_LL, keyboardHookProc, 0, 0)
+
+	return keyboardEventChan
+}
+
+// UninstallKeyboardHook removes the WH_KEYBOARD_LL hook installed by InstallKeyboardHook
+func UninstallKeyboardHook() bool {
+	ok := UnhookWindowsHookEx(keyboardHookHandle)
+	close(keyboardEventChan)
+
+	return ok
+}
+
+// keyboardHookProc is the HOOKPROC for WH_KEYBOARD_LL
+//
+// LPARAM is a pointer to a KBDLLHOOKSTRUCT struct
+// https://docs.microsoft.com/en-us/previous-versions/windows/desktop/legacy/ms644985(v=vs.85)
+func keyboardHookProc(nCode int, wParam WPARAM, lParam LPARAM) LRESULT {
+	if nCode >= 0 {
+		hookStruct := (*tagKBDLLHOOKSTRUCT)(unsafe.Pointer(uintptr(lParam)))
+
+		// This is one of our own replayed keystrokes re-entering the hook chain via SendInput;
+		// let it through without feeding it back into the sequence matcher or event channel
+		if hookStruct.dwExtraInfo == ReplaySentinel {
+			return CallNextHookEx(0, nCode, wParam, lParam)
+		}
+
+		keyUp := uint32(wParam) == WM_KEYUP || uint32(wParam) == WM_SYSKEYUP
+
+		if !keyUp && handleSequenceKeyDown(hookStruct) {
+			return 1 // swallowed: consumed by the sequence matcher
+		}
+
+		if keyUp && handleSequenceKeyUp(hookStruct.vkCode) {
+			return 1 // swallowed: matches a key-down the sequence matcher already consumed
+		}
+
+		event := KeyEvent{
+			VkCode:   hookStruct.vkCode,
+			ScanCode: hookStruct.scanCode,
+			Flags:    hookStruct.flags,
+			Time:     hookStruct.time,
+			KeyUp:    keyUp,
+		}
+
+		select {
+		case keyboardEventChan <- event:
+		default:
+			// Drop the event rather than block the hook chain if nobody is reading fast enough
+		}
+	}
+
+	return CallNextHookEx(0, nCode, wParam, lParam)
+}
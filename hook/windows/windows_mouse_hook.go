@@ -0,0 +1,240 @@
+//go:build windows
+
+package windows
+
+import (
+	"log"
+	"unsafe"
+)
+
+// Low-level mouse input event
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-msllhookstruct
+type tagMSLLHOOKSTRUCT struct {
+	pt          POINT
+	mouseData   DWORD
+	flags       DWORD
+	time        DWORD
+	dwExtraInfo ULONG_PTR
+}
+
+// POINT is a 2D screen coordinate
+// https://docs.microsoft.com/en-us/windows/win32/api/windef/ns-windef-point
+type POINT struct {
+	X int32
+	Y int32
+}
+
+// Simulated mouse event
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-mouseinput
+type MOUSEINPUT struct {
+	Dx          int32
+	Dy          int32
+	MouseData   uint32
+	DwFlags     uint32
+	Time        uint32
+	DwExtraInfo uintptr
+}
+
+// Input event for a mouse action, mirroring TagINPUT for INPUT_MOUSE
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-input
+type TagMouseINPUT struct {
+	InputType uint32
+	Mi        MOUSEINPUT
+	padding   uint64
+}
+
+// MouseEvent is emitted on the channel returned by InstallMouseHook for every
+// WM_MOUSEMOVE/WM_*BUTTONDOWN/WM_*BUTTONUP/WM_MOUSEWHEEL captured by the hook
+type MouseEvent struct {
+	X      int32
+	Y      int32
+	Button string
+	Delta  int16
+	Time   DWORD
+}
+
+const (
+	// Types of hook procedure:
+	// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-setwindowshookexw
+	WH_MOUSE_LL = 14
+
+	// Mouse messages:
+	// https://docs.microsoft.com/en-us/windows/win32/inputdev/mouse-input-notifications
+	WM_MOUSEMOVE   = 0x0200
+	WM_LBUTTONDOWN = 0x0201
+	WM_LBUTTONUP   = 0x0202
+	WM_RBUTTONDOWN = 0x0204
+	WM_RBUTTONUP   = 0x0205
+	WM_MBUTTONDOWN = 0x0207
+	WM_MBUTTONUP   = 0x0208
+	WM_MOUSEWHEEL  = 0x020A
+	WM_XBUTTONDOWN = 0x020B
+	WM_XBUTTONUP   = 0x020C
+
+	// XButton identifiers, decoded from the high word of MSLLHOOKSTRUCT.mouseData:
+	// https://docs.microsoft.com/en-us/windows/win32/inputdev/wm-xbuttondown
+	XBUTTON1 = 0x0001
+	XBUTTON2 = 0x0002
+
+	// WHEEL_DELTA is one notch of a mouse wheel, used to scale MOUSEINPUT.MouseData for WM_MOUSEWHEEL
+	WHEEL_DELTA = 120
+
+	// Mouse event flags for MOUSEINPUT.DwFlags:
+	// https://docs.microsoft.com/en-us/windows/win32/api/winuser/ns-winuser-mouseinput#members
+	MOUSEEVENTF_MOVE       = 0x0001
+	MOUSEEVENTF_LEFTDOWN   = 0x0002
+	MOUSEEVENTF_LEFTUP     = 0x0004
+	MOUSEEVENTF_RIGHTDOWN  = 0x0008
+	MOUSEEVENTF_RIGHTUP    = 0x0010
+	MOUSEEVENTF_MIDDLEDOWN = 0x0020
+	MOUSEEVENTF_MIDDLEUP   = 0x0040
+	MOUSEEVENTF_XDOWN      = 0x0080
+	MOUSEEVENTF_XUP        = 0x0100
+	MOUSEEVENTF_WHEEL      = 0x0800
+	MOUSEEVENTF_ABSOLUTE   = 0x8000
+)
+
+var (
+	mouseHookHandle HHOOK
+	mouseEventChan  chan MouseEvent
+)
+
+// InstallMouseHook installs a WH_MOUSE_LL hook and returns a channel of MouseEvent
+// so shortcut definitions can trigger on mouse gestures (e.g. Shift+RClick).
+// Call UninstallMouseHook to remove the hook and close the channel.
+func InstallMouseHook() <-chan MouseEvent {
+	mouseEventChan = make(chan MouseEvent, 64)
+	mouseHookHandle = SetWindowsHookExW(WH_MOUSE_LL, mouseHookProc, 0, 0)
+
+	return mouseEventChan
+}
+
+// UninstallMouseHook removes the WH_MOUSE_LL hook installed by InstallMouseHook
+func UninstallMouseHook() bool {
+	ok := UnhookWindowsHookEx(mouseHookHandle)
+	close(mouseEventChan)
+
+	return ok
+}
+
+// mouseHookProc is the HOOKPROC for WH_MOUSE_LL
+//
+// LPARAM is a pointer to a MSLLHOOKSTRUCT struct
+// https://docs.microsoft.com/en-us/previous-versions/windows/desktop/legacy/ms644986(v=vs.85)
+func mouseHookProc(nCode int, wParam WPARAM, lParam LPARAM) LRESULT {
+	if nCode >= 0 {
+		hookStruct := (*tagMSLLHOOKSTRUCT)(unsafe.Pointer(uintptr(lParam)))
+
+		if event, ok := toMouseEvent(uint32(wParam), hookStruct); ok {
+			select {
+			case mouseEventChan <- event:
+			default:
+				// Drop the event rather than block the hook chain if nobody is reading fast enough
+			}
+		}
+	}
+
+	return CallNextHookEx(0, nCode, wParam, lParam)
+}
+
+// toMouseEvent translates a WM_* mouse message and its MSLLHOOKSTRUCT payload into a MouseEvent
+func toMouseEvent(wMsg uint32, hookStruct *tagMSLLHOOKSTRUCT) (MouseEvent, bool) {
+	event := MouseEvent{
+		X:    hookStruct.pt.X,
+		Y:    hookStruct.pt.Y,
+		Time: hookStruct.time,
+	}
+
+	switch wMsg {
+	case WM_MOUSEMOVE:
+		event.Button = "Move"
+	case WM_LBUTTONDOWN:
+		event.Button = "LButtonDown"
+	case WM_LBUTTONUP:
+		event.Button = "LButtonUp"
+	case WM_RBUTTONDOWN:
+		event.Button = "RButtonDown"
+	case WM_RBUTTONUP:
+		event.Button = "RButtonUp"
+	case WM_MBUTTONDOWN:
+		event.Button = "MButtonDown"
+	case WM_MBUTTONUP:
+		event.Button = "MButtonUp"
+	case WM_MOUSEWHEEL:
+		event.Button = "Wheel"
+		event.Delta = int16(hiWord(uint32(hookStruct.mouseData)))
+	case WM_XBUTTONDOWN, WM_XBUTTONUP:
+		if hiWord(uint32(hookStruct.mouseData)) == XBUTTON2 {
+			event.Button = "XButton2"
+		} else {
+			event.Button = "XButton1"
+		}
+
+		if wMsg == WM_XBUTTONUP {
+			event.Button += "Up"
+		} else {
+			event.Button += "Down"
+		}
+	default:
+		return MouseEvent{}, false
+	}
+
+	return event, true
+}
+
+// hiWord returns the high-order word of a 32-bit value, used to decode MSLLHOOKSTRUCT.mouseData
+func hiWord(dword uint32) uint16 {
+	return uint16(dword >> 16)
+}
+
+// tagInputMouse creates a base mouse TagMouseINPUT template
+func tagInputMouse() TagMouseINPUT {
+	return TagMouseINPUT{
+		InputType: INPUT_MOUSE,
+	}
+}
+
+// SendMouseClick synthesizes a mouse button press (downFlag) immediately followed by its
+// release (upFlag), e.g. SendMouseClick(MOUSEEVENTF_LEFTDOWN, MOUSEEVENTF_LEFTUP) for a left click
+func SendMouseClick(downFlag uint32, upFlag uint32) uint {
+	down := tagInputMouse()
+	down.Mi.DwFlags = downFlag
+	SendMouseInput(down)
+
+	up := tagInputMouse()
+	up.Mi.DwFlags = upFlag
+
+	return SendMouseInput(up)
+}
+
+// SendMouseMove synthesizes a relative mouse movement of (dx, dy) from the cursor's current position
+func SendMouseMove(dx int32, dy int32) uint {
+	input := tagInputMouse()
+	input.Mi.Dx = dx
+	input.Mi.Dy = dy
+	input.Mi.DwFlags = MOUSEEVENTF_MOVE
+
+	return SendMouseInput(input)
+}
+
+// SendMouseWheel synthesizes a mouse wheel scroll, delta in multiples of WHEEL_DELTA
+func SendMouseWheel(delta int32) uint {
+	input := tagInputMouse()
+	input.Mi.MouseData = uint32(delta)
+	input.Mi.DwFlags = MOUSEEVENTF_WHEEL
+
+	return SendMouseInput(input)
+}
+
+// SendMouseInput simulates a single mouse input event to the operating system
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-sendinput
+func SendMouseInput(pInput TagMouseINPUT) uint {
+	result, _, err := winDLLUser32_SendInput.Call(uintptr(1), uintptr(unsafe.Pointer(&pInput)), uintptr(unsafe.Sizeof(pInput)))
+
+	if result == 0 {
+		log.Println("SendInput error:", err)
+		return 0
+	}
+
+	return uint(result)
+}
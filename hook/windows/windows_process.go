@@ -0,0 +1,109 @@
+//go:build windows
+
+package windows
+
+import (
+	"log"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// Access rights requested when opening the foreground process to read its image name:
+	// https://docs.microsoft.com/en-us/windows/win32/procthread/process-security-and-access-rights
+	PROCESS_QUERY_LIMITED_INFORMATION = 0x1000
+)
+
+var (
+	winDLLKernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	// User32.dll procedures
+	winDLLUser32_GetForegroundWindow      = winDLLUser32.NewProc("GetForegroundWindow")
+	winDLLUser32_GetWindowThreadProcessId = winDLLUser32.NewProc("GetWindowThreadProcessId")
+	winDLLUser32_GetClassNameW            = winDLLUser32.NewProc("GetClassNameW")
+	winDLLUser32_GetWindowTextW           = winDLLUser32.NewProc("GetWindowTextW")
+
+	// Kernel32.dll procedures
+	winDLLKernel32_OpenProcess                = winDLLKernel32.NewProc("OpenProcess")
+	winDLLKernel32_CloseHandle                = winDLLKernel32.NewProc("CloseHandle")
+	winDLLKernel32_QueryFullProcessImageNameW = winDLLKernel32.NewProc("QueryFullProcessImageNameW")
+)
+
+// GetForegroundWindow retrieves a handle to the foreground window (the window the user is
+// currently working with)
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getforegroundwindow
+func GetForegroundWindow() HWND {
+	result, _, _ := winDLLUser32_GetForegroundWindow.Call()
+
+	return HWND(result)
+}
+
+// GetWindowThreadProcessId retrieves the process ID that created the window identified by hWnd
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getwindowthreadprocessid
+func GetWindowThreadProcessId(hWnd HWND) DWORD {
+	var processID DWORD
+	winDLLUser32_GetWindowThreadProcessId.Call(uintptr(hWnd), uintptr(unsafe.Pointer(&processID)))
+
+	return processID
+}
+
+// GetClassNameW retrieves the window class name of the window identified by hWnd
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getclassnamew
+func GetClassNameW(hWnd HWND) string {
+	buf := make([]uint16, 256)
+	n, _, err := winDLLUser32_GetClassNameW.Call(uintptr(hWnd), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+
+	if n == 0 {
+		log.Println("GetClassNameW error:", err)
+		return ""
+	}
+
+	return syscall.UTF16ToString(buf[:n])
+}
+
+// GetWindowTextW retrieves the title bar text of the window identified by hWnd
+// https://docs.microsoft.com/en-us/windows/win32/api/winuser/nf-winuser-getwindowtextw
+func GetWindowTextW(hWnd HWND) string {
+	buf := make([]uint16, 256)
+	n, _, err := winDLLUser32_GetWindowTextW.Call(uintptr(hWnd), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+
+	if n == 0 {
+		log.Println("GetWindowTextW error:", err)
+		return ""
+	}
+
+	return syscall.UTF16ToString(buf[:n])
+}
+
+// GetForegroundProcessExeName returns the base executable name (e.g. "notepad.exe") of the
+// process that owns the current foreground window, used to apply a shortcut's per-process filter
+func GetForegroundProcessExeName() string {
+	processID := GetWindowThreadProcessId(GetForegroundWindow())
+	if processID == 0 {
+		return ""
+	}
+
+	handle, _, err := winDLLKernel32_OpenProcess.Call(uintptr(PROCESS_QUERY_LIMITED_INFORMATION), 0, uintptr(processID))
+	if handle == 0 {
+		log.Println("OpenProcess error:", err)
+		return ""
+	}
+	defer winDLLKernel32_CloseHandle.Call(handle)
+
+	buf := make([]uint16, 260)
+	size := DWORD(len(buf))
+	ok, _, err := winDLLKernel32_QueryFullProcessImageNameW.Call(handle, 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if ok == 0 {
+		log.Println("QueryFullProcessImageNameW error:", err)
+		return ""
+	}
+
+	fullPath := syscall.UTF16ToString(buf[:size])
+	for i := len(fullPath) - 1; i >= 0; i-- {
+		if fullPath[i] == '\\' || fullPath[i] == '/' {
+			return fullPath[i+1:]
+		}
+	}
+
+	return fullPath
+}
@@ -0,0 +1,231 @@
+//go:build windows
+
+package windows
+
+import (
+	"sync"
+	"time"
+)
+
+// Modifier bitmask values for SequenceStep.Modifiers, combined with GetKeyState to precisely
+// track which of Shift/Ctrl/Alt/Win are held down at each step of a sequence
+const (
+	ModShift uint32 = 1 << iota
+	ModControl
+	ModAlt
+	ModWin
+)
+
+// ReplaySentinel tags KEYBDINPUT.DwExtraInfo on any synthesized event that should re-enter the
+// hook chain without being treated as real user input: keyboardHookProc recognizes it and
+// short-circuits the event instead of feeding it back into the sequence matcher (SendInput
+// re-enters the same low-level hook chain). Callers outside this package that synthesize input
+// on the user's behalf (e.g. shortcut.Execute) must tag their events with it too, or their
+// synthesized keystrokes get matched against registered sequences as if the user had typed them.
+const ReplaySentinel = 0x53434852 // "SCHR": Short_Cutteer replay
+
+// DefaultSequenceTimeout is how long the matcher waits for the next step of a sequence (e.g. the
+// gap between "Ctrl+K" and "Ctrl+D" in a "Ctrl+K, Ctrl+D" chord) before giving up and replaying
+var DefaultSequenceTimeout = 600 * time.Millisecond
+
+// SequenceStep is one step of a chorded/sequence hotkey: the modifiers that must be held plus
+// the virtual key that advances the trie
+type SequenceStep struct {
+	Modifiers uint32
+	VkCode    DWORD
+}
+
+type sequenceNode struct {
+	children map[SequenceStep]*sequenceNode
+	onMatch  func()
+}
+
+func newSequenceNode() *sequenceNode {
+	return &sequenceNode{children: map[SequenceStep]*sequenceNode{}}
+}
+
+type bufferedKey struct {
+	vkCode   DWORD
+	scanCode DWORD
+	flags    DWORD
+}
+
+var (
+	sequenceMu         sync.Mutex
+	sequenceRoot       = newSequenceNode()
+	sequenceCurrent    = sequenceRoot
+	sequenceBuffer     []bufferedKey
+	sequenceDeadline   time.Time
+	sequenceTickerOnce sync.Once
+
+	// swallowedVkUps counts, per vkCode, how many of its key-downs handleSequenceKeyDown has
+	// swallowed without a matching key-up yet. Without this, swallowing only the key-down half of
+	// a registered sequence (e.g. "Ctrl+K, Ctrl+D") leaks the key-up half through as a bare,
+	// unmatched WM_KEYUP to whatever has focus. Guarded by sequenceMu.
+	swallowedVkUps = map[DWORD]int{}
+)
+
+// RegisterSequence adds a chord/sequence of steps to the matcher; onMatch fires, and the whole
+// sequence is swallowed, once every step has been typed within DefaultSequenceTimeout of the last
+func RegisterSequence(steps []SequenceStep, onMatch func()) {
+	sequenceMu.Lock()
+	defer sequenceMu.Unlock()
+
+	node := sequenceRoot
+	for _, step := range steps {
+		child, ok := node.children[step]
+		if !ok {
+			child = newSequenceNode()
+			node.children[step] = child
+		}
+		node = child
+	}
+	node.onMatch = onMatch
+
+	startSequenceTimeoutLoop()
+}
+
+// ClearSequences removes every registered sequence, e.g. before reloading a shortcuts config
+func ClearSequences() {
+	sequenceMu.Lock()
+	defer sequenceMu.Unlock()
+
+	sequenceRoot = newSequenceNode()
+	sequenceCurrent = sequenceRoot
+	sequenceBuffer = nil
+	swallowedVkUps = map[DWORD]int{}
+}
+
+// startSequenceTimeoutLoop lazily starts the goroutine that replays a stalled, mid-sequence
+// buffer once DefaultSequenceTimeout elapses without the next expected key arriving
+func startSequenceTimeoutLoop() {
+	sequenceTickerOnce.Do(func() {
+		go func() {
+			ticker := time.NewTicker(50 * time.Millisecond)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				sequenceMu.Lock()
+				if sequenceCurrent != sequenceRoot && time.Now().After(sequenceDeadline) {
+					replayBufferedLocked()
+					sequenceCurrent = sequenceRoot
+				}
+				sequenceMu.Unlock()
+			}
+		}()
+	})
+}
+
+// CurrentModifierMask reads the live state of Shift/Ctrl/Alt/Win via GetKeyState, exported so
+// other packages that match their own triggers against live key state (e.g. shortcut's mouse
+// gesture matching) can reuse it instead of re-reading GetKeyState themselves
+func CurrentModifierMask() uint32 {
+	var mask uint32
+
+	if uint16(GetKeyState(VK_SHIFT))&0x8000 != 0 {
+		mask |= ModShift
+	}
+	if uint16(GetKeyState(VK_CONTROL))&0x8000 != 0 {
+		mask |= ModControl
+	}
+	if uint16(GetKeyState(VK_MENU))&0x8000 != 0 {
+		mask |= ModAlt
+	}
+	if uint16(GetKeyState(VK_LWIN))&0x8000 != 0 || uint16(GetKeyState(VK_RWIN))&0x8000 != 0 {
+		mask |= ModWin
+	}
+
+	return mask
+}
+
+// handleSequenceKeyDown advances the trie with a WM_KEYDOWN event. It returns true if the event
+// should be swallowed (it continued or completed a sequence), false if it should fall through to
+// the caller's normal event handling.
+func handleSequenceKeyDown(hookStruct *tagKBDLLHOOKSTRUCT) bool {
+	sequenceMu.Lock()
+	defer sequenceMu.Unlock()
+
+	if len(sequenceRoot.children) == 0 {
+		return false
+	}
+
+	now := time.Now()
+	if sequenceCurrent != sequenceRoot && now.After(sequenceDeadline) {
+		replayBufferedLocked()
+		sequenceCurrent = sequenceRoot
+	}
+
+	step := SequenceStep{Modifiers: CurrentModifierMask(), VkCode: hookStruct.vkCode}
+	next, ok := sequenceCurrent.children[step]
+	if !ok {
+		wasMidSequence := sequenceCurrent != sequenceRoot
+		sequenceCurrent = sequenceRoot
+
+		if wasMidSequence {
+			// Leader key(s) were pressed but this key doesn't continue the sequence: replay what
+			// was buffered so the user's typing is preserved, then let this key fall through
+			replayBufferedLocked()
+		}
+
+		return false
+	}
+
+	sequenceBuffer = append(sequenceBuffer, bufferedKey{vkCode: hookStruct.vkCode, scanCode: hookStruct.scanCode, flags: hookStruct.flags})
+	sequenceDeadline = now.Add(DefaultSequenceTimeout)
+
+	swallowedVkUps[hookStruct.vkCode]++
+
+	if next.onMatch != nil && len(next.children) == 0 {
+		onMatch := next.onMatch
+		sequenceCurrent = sequenceRoot
+		sequenceBuffer = nil
+		onMatch()
+		return true
+	}
+
+	sequenceCurrent = next
+	return true
+}
+
+// handleSequenceKeyUp reports whether vkCode's key-up should be swallowed because its key-down
+// was already swallowed by handleSequenceKeyDown as part of an in-progress or completed sequence.
+func handleSequenceKeyUp(vkCode DWORD) bool {
+	sequenceMu.Lock()
+	defer sequenceMu.Unlock()
+
+	if swallowedVkUps[vkCode] <= 0 {
+		return false
+	}
+
+	swallowedVkUps[vkCode]--
+	if swallowedVkUps[vkCode] == 0 {
+		delete(swallowedVkUps, vkCode)
+	}
+
+	return true
+}
+
+// replayBufferedLocked re-injects every buffered key as a tap (down+up) via a single SendInputs
+// call, tagged with ReplaySentinel so keyboardHookProc ignores them on their way back through the
+// hook chain. Batching the whole buffer atomically keeps a replayed chord from being interleaved
+// with real user input. Must be called with sequenceMu held.
+func replayBufferedLocked() {
+	events := make([]TagINPUT, 0, 2*len(sequenceBuffer))
+
+	for _, k := range sequenceBuffer {
+		down := TagINPUT{InputType: INPUT_KEYBOARD}
+		down.Ki.WVk = uint16(k.vkCode)
+		down.Ki.WScan = uint16(k.scanCode)
+		down.Ki.DwFlags = LLKHFToSendInputFlags(k.flags)
+		down.Ki.DwExtraInfo = ReplaySentinel
+
+		up := down
+		up.Ki.DwFlags = LLKHFToSendInputFlags(k.flags) | KEYEVENTF_KEYUP
+
+		events = append(events, down, up)
+	}
+
+	SendInputs(events)
+
+	sequenceBuffer = nil
+}
@@ -0,0 +1,56 @@
+package journal
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	saltSize  = 16
+	nonceSize = 12 // AES-GCM standard nonce size
+	keySize   = 32 // AES-256
+
+	// scrypt cost parameters; N is the default used by most password managers as of this writing
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// deriveKey derives an AES-256 key from a user-supplied passphrase and a random per-journal salt
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("journal: derive key: %w", err)
+	}
+
+	return key, nil
+}
+
+// newGCM builds an AES-GCM AEAD from a derived key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("journal: new AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("journal: new GCM: %w", err)
+	}
+
+	return gcm, nil
+}
+
+// randomBytes returns n cryptographically random bytes
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("journal: read random bytes: %w", err)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,80 @@
+package journal
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Reader decrypts and decodes Records from a single journal file written by Writer
+type Reader struct {
+	file *os.File
+	gcm  cipher.AEAD
+}
+
+// NewReader opens a single journal file and derives its AES-256 key from passphrase
+func NewReader(path string, passphrase string) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("journal: open %s: %w", path, err)
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(f, salt); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("journal: read salt header: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &Reader{file: f, gcm: gcm}, nil
+}
+
+// Next reads and decrypts the next Record, returning io.EOF once the file is exhausted
+func (r *Reader) Next() (Record, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r.file, lenBuf[:]); err != nil {
+		return Record{}, err // io.EOF on a clean end of file
+	}
+
+	sealed := make([]byte, binary.BigEndian.Uint32(lenBuf[:]))
+	if _, err := io.ReadFull(r.file, sealed); err != nil {
+		return Record{}, fmt.Errorf("journal: read frame: %w", err)
+	}
+
+	if len(sealed) < nonceSize {
+		return Record{}, fmt.Errorf("journal: truncated frame")
+	}
+	nonce, ciphertext := sealed[:nonceSize], sealed[nonceSize:]
+
+	plain, err := r.gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return Record{}, fmt.Errorf("journal: decrypt frame (wrong passphrase?): %w", err)
+	}
+
+	var record Record
+	if err := gob.NewDecoder(bytes.NewReader(plain)).Decode(&record); err != nil {
+		return Record{}, fmt.Errorf("journal: decode record: %w", err)
+	}
+
+	return record, nil
+}
+
+// Close closes the underlying file
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
@@ -0,0 +1,31 @@
+// Package journal records low-level keyboard/mouse events to a rotating, AES-GCM-encrypted
+// on-disk log, and decodes them back for the replay tooling in cmd/replay.
+package journal
+
+// EventKind distinguishes a keyboard Record from a mouse Record
+type EventKind uint8
+
+const (
+	EventKeyboard EventKind = iota
+	EventMouse
+)
+
+// Record is a single journaled low-level input event. ForegroundExe is captured alongside the
+// event so a replay (or a later audit) can tell which application the user was typing into.
+type Record struct {
+	Time          int64
+	Kind          EventKind
+	KeyUp         bool
+	VkCode        uint32
+	ScanCode      uint32
+	Flags         uint32
+	MouseX        int32
+	MouseY        int32
+	MouseButton   string
+	MouseDelta    int16
+	ForegroundExe string
+
+	// Redacted is true when Writer masked this record's keystroke because the foreground window
+	// looked like a password field; VkCode/ScanCode are zeroed in that case
+	Redacted bool
+}
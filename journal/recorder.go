@@ -0,0 +1,47 @@
+//go:build windows
+
+package journal
+
+import (
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+)
+
+// RecordKeyboard journals every KeyEvent from events until the channel is closed. It is opt-in:
+// callers choose whether to also install win.InstallKeyboardHook alongside it.
+func RecordKeyboard(w *Writer, events <-chan win.KeyEvent) {
+	for event := range events {
+		record := Record{
+			Time:          int64(event.Time),
+			Kind:          EventKeyboard,
+			KeyUp:         event.KeyUp,
+			VkCode:        uint32(event.VkCode),
+			ScanCode:      uint32(event.ScanCode),
+			Flags:         uint32(event.Flags),
+			ForegroundExe: win.GetForegroundProcessExeName(),
+		}
+
+		if err := w.Write(record, win.GetWindowTextW(win.GetForegroundWindow())); err != nil {
+			// Best-effort: a single failed write shouldn't stop the recorder
+			continue
+		}
+	}
+}
+
+// RecordMouse journals every MouseEvent from events until the channel is closed
+func RecordMouse(w *Writer, events <-chan win.MouseEvent) {
+	for event := range events {
+		record := Record{
+			Time:          int64(event.Time),
+			Kind:          EventMouse,
+			MouseX:        event.X,
+			MouseY:        event.Y,
+			MouseButton:   event.Button,
+			MouseDelta:    event.Delta,
+			ForegroundExe: win.GetForegroundProcessExeName(),
+		}
+
+		if err := w.Write(record, win.GetWindowTextW(win.GetForegroundWindow())); err != nil {
+			continue
+		}
+	}
+}
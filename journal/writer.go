@@ -0,0 +1,169 @@
+package journal
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// MaxFileSize is the rotation threshold: once a journal file reaches this size, Writer closes it
+// and opens the next numbered file alongside it
+const MaxFileSize = 64 * 1024 * 1024
+
+// passwordTitleHeuristic matches foreground window titles Writer treats as password fields when
+// redaction is enabled, masking the keystrokes typed into them instead of journaling them in the
+// clear. This is a weak, best-effort heuristic: most real login/password fields (browser tabs
+// for a bank or SSO page, a desktop app's login dialog, ...) don't put any of these words in
+// their window title, so redaction catches only the minority of cases that do. Don't treat
+// redact=true as a guarantee that credentials won't end up in the journal in the clear - the only
+// reliable way to keep a credential out of it is to not type it while recording.
+var passwordTitleHeuristic = []string{"password", "passwd", "passphrase", "login", "log in", "sign in", "credential"}
+
+// Writer appends AES-GCM-encrypted Records to a rotating sequence of files: base.0, base.1, ...
+type Writer struct {
+	mu      sync.Mutex
+	dir     string
+	base    string
+	salt    []byte
+	gcm     cipher.AEAD
+	redact  bool
+	file    *os.File
+	written int64
+	index   int
+}
+
+// NewWriter creates (or appends to) a journal under dir named base.N, deriving an AES-256 key
+// from passphrase. Pass redact=true to mask keystrokes typed into windows that look like
+// password fields (per passwordTitleHeuristic) instead of journaling them in the clear - see that
+// heuristic's doc comment for why this is best-effort only, not a real guarantee.
+func NewWriter(dir string, base string, passphrase string, redact bool) (*Writer, error) {
+	salt, err := randomBytes(saltSize)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Writer{dir: dir, base: base, salt: salt, gcm: gcm, redact: redact}
+	if err := w.openFile(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// openFile opens (creating if necessary) the current index's journal file, writing the salt
+// header if the file is new
+func (w *Writer) openFile() error {
+	path := filepath.Join(w.dir, fmt.Sprintf("%s.%d", w.base, w.index))
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("journal: open %s: %w", path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	if info.Size() == 0 {
+		if _, err := f.Write(w.salt); err != nil {
+			f.Close()
+			return fmt.Errorf("journal: write salt header: %w", err)
+		}
+	}
+
+	w.file = f
+	w.written = info.Size()
+
+	return nil
+}
+
+// Write appends record to the journal, masking its keystroke first if Writer was opened with
+// redact and foregroundTitle looks like a password field
+func (w *Writer) Write(record Record, foregroundTitle string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.redact && looksLikePasswordField(foregroundTitle) {
+		record.VkCode = 0
+		record.ScanCode = 0
+		record.Redacted = true
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(record); err != nil {
+		return fmt.Errorf("journal: encode record: %w", err)
+	}
+
+	nonce, err := randomBytes(nonceSize)
+	if err != nil {
+		return err
+	}
+
+	sealed := w.gcm.Seal(nonce, nonce, buf.Bytes(), nil)
+
+	frame := make([]byte, 4+len(sealed))
+	binary.BigEndian.PutUint32(frame, uint32(len(sealed)))
+	copy(frame[4:], sealed)
+
+	n, err := w.file.Write(frame)
+	if err != nil {
+		return fmt.Errorf("journal: write frame: %w", err)
+	}
+	w.written += int64(n)
+
+	if w.written >= MaxFileSize {
+		return w.rotate()
+	}
+
+	return nil
+}
+
+// rotate closes the current file and opens the next index, reusing the same derived key
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	w.index++
+
+	return w.openFile()
+}
+
+// Close closes the journal's current file
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file.Close()
+}
+
+// looksLikePasswordField reports whether a foreground window title matches the password-field
+// heuristic
+func looksLikePasswordField(title string) bool {
+	lower := strings.ToLower(title)
+	for _, needle := range passwordTitleHeuristic {
+		if strings.Contains(lower, needle) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,134 @@
+package journal
+
+import (
+	"io"
+	"testing"
+)
+
+func TestWriterReaderRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := NewWriter(dir, "test.journal", "correct horse battery staple", false)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	records := []Record{
+		{Time: 1, Kind: EventKeyboard, VkCode: 0x41, ScanCode: 30, ForegroundExe: "notepad.exe"},
+		{Time: 2, Kind: EventKeyboard, VkCode: 0x41, KeyUp: true, ForegroundExe: "notepad.exe"},
+		{Time: 3, Kind: EventMouse, MouseX: 10, MouseY: 20, MouseButton: "LButtonDown"},
+	}
+
+	for _, r := range records {
+		if err := w.Write(r, "Untitled - Notepad"); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(w.file.Name(), "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer r.Close()
+
+	for i, want := range records {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("Next() record %d: %v", i, err)
+		}
+		if got != want {
+			t.Fatalf("record %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("Next() past the last record = %v, want io.EOF", err)
+	}
+}
+
+func TestWriterRedactsPasswordFields(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := NewWriter(dir, "test.journal", "passphrase", true)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+
+	record := Record{Time: 1, Kind: EventKeyboard, VkCode: 0x50, ScanCode: 25}
+	if err := w.Write(record, "Sign in - Example"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(w.file.Name(), "passphrase")
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer r.Close()
+
+	got, err := r.Next()
+	if err != nil {
+		t.Fatalf("Next: %v", err)
+	}
+	if !got.Redacted || got.VkCode != 0 || got.ScanCode != 0 {
+		t.Fatalf("Next() = %+v, want redacted with zeroed VkCode/ScanCode", got)
+	}
+}
+
+func TestReaderWrongPassphrase(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := NewWriter(dir, "test.journal", "right passphrase", false)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	if err := w.Write(Record{Time: 1, Kind: EventKeyboard}, ""); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	path := w.file.Name()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	r, err := NewReader(path, "wrong passphrase")
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	defer r.Close()
+
+	if _, err := r.Next(); err == nil {
+		t.Fatal("Next() with the wrong passphrase succeeded, want a decrypt error")
+	}
+}
+
+func TestWriterRotate(t *testing.T) {
+	dir := t.TempDir()
+
+	w, err := NewWriter(dir, "test.journal", "passphrase", false)
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	defer w.Close()
+
+	if err := w.Write(Record{Time: 1, Kind: EventKeyboard}, ""); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	firstPath := w.file.Name()
+	if err := w.rotate(); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+
+	if w.file.Name() == firstPath {
+		t.Fatalf("rotate() kept writing to %s, want a new file", firstPath)
+	}
+	if w.index != 1 {
+		t.Fatalf("rotate() left index = %d, want 1", w.index)
+	}
+}
@@ -0,0 +1,182 @@
+//go:build windows
+
+package shortcut
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+)
+
+// ActionKind identifies which action primitive an Action executes
+type ActionKind int
+
+const (
+	ActionKeyDown ActionKind = iota
+	ActionKeyUp
+	ActionType
+	ActionSleep
+	ActionSendCombo
+	ActionMouseClick
+	ActionPaste
+)
+
+// Action is a single step of a shortcut's action list, parsed from one line of config
+type Action struct {
+	Kind   ActionKind
+	Key    uint16
+	Text   string
+	Millis int
+	Combo  TriggerStep
+	Button string
+}
+
+// mouseClickFlags maps a button name to its MOUSEEVENTF down/up flag pair
+var mouseClickFlags = map[string][2]uint32{
+	"left":   {win.MOUSEEVENTF_LEFTDOWN, win.MOUSEEVENTF_LEFTUP},
+	"right":  {win.MOUSEEVENTF_RIGHTDOWN, win.MOUSEEVENTF_RIGHTUP},
+	"middle": {win.MOUSEEVENTF_MIDDLEDOWN, win.MOUSEEVENTF_MIDDLEUP},
+}
+
+// ParseAction parses one action primitive: keydown <key>, keyup <key>, type "text", sleep <ms>,
+// sendcombo Ctrl+Shift+V, mouseclick [left|right|middle], paste
+func ParseAction(s string) (Action, error) {
+	verb, arg, _ := strings.Cut(strings.TrimSpace(s), " ")
+	arg = strings.TrimSpace(arg)
+
+	switch strings.ToLower(verb) {
+	case "keydown":
+		vk, err := vkFromName(arg)
+		if err != nil {
+			return Action{}, err
+		}
+		return Action{Kind: ActionKeyDown, Key: vk}, nil
+
+	case "keyup":
+		vk, err := vkFromName(arg)
+		if err != nil {
+			return Action{}, err
+		}
+		return Action{Kind: ActionKeyUp, Key: vk}, nil
+
+	case "type":
+		text, err := strconv.Unquote(arg)
+		if err != nil {
+			return Action{}, fmt.Errorf("type action %q: expected a quoted string: %w", s, err)
+		}
+		return Action{Kind: ActionType, Text: text}, nil
+
+	case "sleep":
+		ms, err := strconv.Atoi(arg)
+		if err != nil {
+			return Action{}, fmt.Errorf("sleep action %q: expected an integer millisecond count: %w", s, err)
+		}
+		return Action{Kind: ActionSleep, Millis: ms}, nil
+
+	case "sendcombo":
+		combo, err := ParseTriggerStep(arg)
+		if err != nil {
+			return Action{}, err
+		}
+		return Action{Kind: ActionSendCombo, Combo: combo}, nil
+
+	case "mouseclick":
+		button := strings.ToLower(arg)
+		if button == "" {
+			button = "left"
+		}
+		if _, ok := mouseClickFlags[button]; !ok {
+			return Action{}, fmt.Errorf("mouseclick action %q: unknown button %q", s, button)
+		}
+		return Action{Kind: ActionMouseClick, Button: button}, nil
+
+	case "paste":
+		return Action{Kind: ActionPaste}, nil
+
+	default:
+		return Action{}, fmt.Errorf("unknown action %q", s)
+	}
+}
+
+// Execute runs a single action, synthesizing the corresponding keyboard/mouse input
+func Execute(a Action) error {
+	switch a.Kind {
+	case ActionKeyDown:
+		win.SendInputs([]win.TagINPUT{keyEvent(a.Key, false)})
+	case ActionKeyUp:
+		win.SendInputs([]win.TagINPUT{keyEvent(a.Key, true)})
+	case ActionType:
+		typeText(a.Text)
+	case ActionSleep:
+		time.Sleep(time.Duration(a.Millis) * time.Millisecond)
+	case ActionSendCombo:
+		sendCombo(a.Combo)
+	case ActionMouseClick:
+		flags := mouseClickFlags[a.Button]
+		win.SendMouseClick(flags[0], flags[1])
+	case ActionPaste:
+		sendCombo(TriggerStep{Modifiers: []uint16{win.VK_CONTROL}, Key: 'V'})
+	default:
+		return fmt.Errorf("unhandled action kind %v", a.Kind)
+	}
+
+	return nil
+}
+
+// keyEvent builds a single key down or key up TagINPUT for vk, tagged with win.ReplaySentinel so
+// it re-enters the keyboard hook as a synthesized event rather than being matched against
+// registered sequences as if the user had typed it
+func keyEvent(vk uint16, keyUp bool) win.TagINPUT {
+	input := win.TagINPUT{InputType: win.INPUT_KEYBOARD}
+	input.Ki.WVk = vk
+	input.Ki.DwExtraInfo = win.ReplaySentinel
+	if keyUp {
+		input.Ki.DwFlags = win.KEYEVENTF_KEYUP
+	}
+
+	return input
+}
+
+// sendCombo presses every modifier down in order, taps the combo's key, then releases the
+// modifiers in reverse order, delivering the whole chord in one SendInputs call so it can't be
+// interleaved with real user input
+func sendCombo(combo TriggerStep) {
+	events := make([]win.TagINPUT, 0, 2*len(combo.Modifiers)+2)
+
+	for _, vk := range combo.Modifiers {
+		events = append(events, keyEvent(vk, false))
+	}
+
+	events = append(events, keyEvent(combo.Key, false), keyEvent(combo.Key, true))
+
+	for i := len(combo.Modifiers) - 1; i >= 0; i-- {
+		events = append(events, keyEvent(combo.Modifiers[i], true))
+	}
+
+	win.SendInputs(events)
+}
+
+// typeText synthesizes a Unicode key down/up pair per rune via KEYEVENTF_UNICODE, so typed text
+// isn't limited to keys present on the keyboard layout; the whole string is sent as one batch.
+// Each event is tagged with win.ReplaySentinel so it re-enters the keyboard hook as synthesized
+// input rather than being matched against registered sequences as if the user had typed it.
+func typeText(text string) {
+	events := make([]win.TagINPUT, 0, 2*len(text))
+
+	for _, r := range text {
+		down := win.TagINPUT{InputType: win.INPUT_KEYBOARD}
+		down.Ki.WScan = uint16(r)
+		down.Ki.DwFlags = win.KEYEVENTF_UNICODE
+		down.Ki.DwExtraInfo = win.ReplaySentinel
+
+		up := down
+		up.Ki.DwFlags = win.KEYEVENTF_UNICODE | win.KEYEVENTF_KEYUP
+
+		events = append(events, down, up)
+	}
+
+	win.SendInputs(events)
+}
@@ -0,0 +1,46 @@
+//go:build windows
+
+package shortcut
+
+import "testing"
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    ActionKind
+		wantErr bool
+	}{
+		{name: "keydown", in: "keydown Ctrl", want: ActionKeyDown},
+		{name: "keyup", in: "keyup Ctrl", want: ActionKeyUp},
+		{name: "type", in: `type "hello"`, want: ActionType},
+		{name: "sleep", in: "sleep 50", want: ActionSleep},
+		{name: "sendcombo", in: "sendcombo Ctrl+Shift+V", want: ActionSendCombo},
+		{name: "mouseclick default", in: "mouseclick", want: ActionMouseClick},
+		{name: "mouseclick right", in: "mouseclick right", want: ActionMouseClick},
+		{name: "paste", in: "paste", want: ActionPaste},
+		{name: "unknown verb", in: "frobnicate", wantErr: true},
+		{name: "keydown unknown key", in: "keydown Nope", wantErr: true},
+		{name: "type unquoted", in: "type hello", wantErr: true},
+		{name: "sleep non-integer", in: "sleep soon", wantErr: true},
+		{name: "mouseclick unknown button", in: "mouseclick nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseAction(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseAction(%q) = %+v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAction(%q) unexpected error: %v", tt.in, err)
+			}
+			if got.Kind != tt.want {
+				t.Fatalf("ParseAction(%q).Kind = %v, want %v", tt.in, got.Kind, tt.want)
+			}
+		})
+	}
+}
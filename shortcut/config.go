@@ -0,0 +1,78 @@
+//go:build windows
+
+// Package shortcut loads user-defined shortcuts from a YAML/JSON config file and interprets
+// their action lists, replacing hardcoded tagInput builders with a general interpreter.
+//
+// The package is Windows-only: it registers triggers directly with hook/windows's
+// WH_KEYBOARD_LL sequence matcher (for in-hook swallow/replay semantics hook.Hooker doesn't
+// expose) and reads process filters via hook/windows's foreground-window helpers. Porting it to
+// hook.Hooker so it runs on the Linux/macOS backends is tracked as future work, not done yet.
+package shortcut
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ShortcutDef is one entry of a shortcuts config: a trigger combo, an optional per-process
+// filter, and the action list to run when the trigger fires in that process
+type ShortcutDef struct {
+	Trigger string   `yaml:"trigger" json:"trigger"`
+	Process string   `yaml:"process,omitempty" json:"process,omitempty"`
+	Actions []string `yaml:"actions" json:"actions"`
+}
+
+// Config is the root of a shortcuts.yaml/shortcuts.json file
+type Config struct {
+	Shortcuts []ShortcutDef `yaml:"shortcuts" json:"shortcuts"`
+}
+
+// LoadConfig reads and validates a shortcuts config file, rejecting unknown virtual keys and
+// action primitives before any shortcut is registered
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read shortcuts config: %w", err)
+	}
+
+	config := &Config{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, config)
+	case ".json":
+		err = json.Unmarshal(data, config)
+	default:
+		return nil, fmt.Errorf("shortcuts config %q: unsupported extension %q", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse shortcuts config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// Validate parses every trigger and action in the config, returning the first error found
+func (c *Config) Validate() error {
+	for i, def := range c.Shortcuts {
+		if _, err := ParseTrigger(def.Trigger); err != nil {
+			return fmt.Errorf("shortcuts[%d]: %w", i, err)
+		}
+
+		for j, action := range def.Actions {
+			if _, err := ParseAction(action); err != nil {
+				return fmt.Errorf("shortcuts[%d].actions[%d]: %w", i, j, err)
+			}
+		}
+	}
+
+	return nil
+}
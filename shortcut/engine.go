@@ -0,0 +1,159 @@
+//go:build windows
+
+package shortcut
+
+import (
+	"log"
+	"strings"
+	"sync"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+)
+
+// compiledShortcut is a ShortcutDef with its trigger and actions already parsed, ready to be
+// registered with the keyboard hook's sequence matcher
+type compiledShortcut struct {
+	trigger Trigger
+	process string
+	actions []Action
+}
+
+// mouseTrigger is a compiled shortcut whose trigger is a mouse gesture (e.g. "Shift+RClick"),
+// matched against live WH_MOUSE_LL events instead of the keyboard hook's sequence matcher
+type mouseTrigger struct {
+	modifiers uint32
+	button    string
+	fire      func()
+}
+
+// Engine holds the shortcuts loaded from a config and registers them with the WH_KEYBOARD_LL
+// hook's sequence matcher, so trigger matching happens inside the hook callback itself. Mouse
+// gesture triggers are matched separately, against WH_MOUSE_LL events read off a goroutine.
+type Engine struct {
+	mu            sync.Mutex
+	shortcuts     []compiledShortcut
+	mouseTriggers []mouseTrigger
+	mouseOnce     sync.Once
+}
+
+// NewEngine creates an Engine with no shortcuts loaded
+func NewEngine() *Engine {
+	return &Engine{}
+}
+
+// Load compiles config's shortcuts and registers them with the hook's sequence matcher,
+// replacing whatever was previously registered
+func (e *Engine) Load(config *Config) error {
+	compiled := make([]compiledShortcut, 0, len(config.Shortcuts))
+
+	for _, def := range config.Shortcuts {
+		trigger, err := ParseTrigger(def.Trigger)
+		if err != nil {
+			return err
+		}
+
+		actions := make([]Action, 0, len(def.Actions))
+		for _, raw := range def.Actions {
+			action, err := ParseAction(raw)
+			if err != nil {
+				return err
+			}
+			actions = append(actions, action)
+		}
+
+		compiled = append(compiled, compiledShortcut{trigger: trigger, process: def.Process, actions: actions})
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.shortcuts = compiled
+	e.mouseTriggers = e.mouseTriggers[:0]
+
+	win.ClearSequences()
+	for _, s := range e.shortcuts {
+		if s.trigger.IsMouse() {
+			step := s.trigger.Steps[0]
+			e.mouseTriggers = append(e.mouseTriggers, mouseTrigger{
+				modifiers: modifierMask(step.Modifiers),
+				button:    step.MouseButton,
+				fire:      e.fire(s),
+			})
+			continue
+		}
+
+		win.RegisterSequence(toHookSteps(s.trigger), e.fire(s))
+	}
+
+	if len(e.mouseTriggers) > 0 {
+		e.mouseOnce.Do(func() { go e.watchMouse() })
+	}
+
+	return nil
+}
+
+// watchMouse installs the WH_MOUSE_LL hook and fires every registered mouse trigger whose button
+// and live modifier state match an incoming event. It's started once, lazily, the first time a
+// config registers a mouse trigger, and runs for the engine's lifetime.
+func (e *Engine) watchMouse() {
+	for event := range win.InstallMouseHook() {
+		mods := win.CurrentModifierMask()
+
+		e.mu.Lock()
+		triggers := e.mouseTriggers
+		e.mu.Unlock()
+
+		for _, t := range triggers {
+			if t.button == event.Button && t.modifiers == mods {
+				t.fire()
+			}
+		}
+	}
+}
+
+// fire returns the onMatch callback registered for a compiled shortcut: it applies the optional
+// process filter and then runs the shortcut's actions in order
+func (e *Engine) fire(s compiledShortcut) func() {
+	return func() {
+		if s.process != "" && !strings.EqualFold(win.GetForegroundProcessExeName(), s.process) {
+			return
+		}
+
+		for _, action := range s.actions {
+			if err := Execute(action); err != nil {
+				log.Println("shortcut action error:", err)
+			}
+		}
+	}
+}
+
+// toHookSteps converts a Trigger's parsed steps into the SequenceStep form the hook/windows
+// sequence matcher keys its trie on
+func toHookSteps(trigger Trigger) []win.SequenceStep {
+	steps := make([]win.SequenceStep, 0, len(trigger.Steps))
+	for _, step := range trigger.Steps {
+		steps = append(steps, win.SequenceStep{Modifiers: modifierMask(step.Modifiers), VkCode: win.DWORD(step.Key)})
+	}
+
+	return steps
+}
+
+// modifierMask converts a list of modifier virtual keys into the bitmask used by SequenceStep
+func modifierMask(modifiers []uint16) uint32 {
+	var mask uint32
+
+	for _, vk := range modifiers {
+		switch vk {
+		case win.VK_SHIFT:
+			mask |= win.ModShift
+		case win.VK_CONTROL:
+			mask |= win.ModControl
+		case win.VK_MENU:
+			mask |= win.ModAlt
+		case win.VK_LWIN, win.VK_RWIN:
+			mask |= win.ModWin
+		}
+	}
+
+	return mask
+}
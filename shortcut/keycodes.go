@@ -0,0 +1,171 @@
+//go:build windows
+
+package shortcut
+
+import (
+	"fmt"
+	"strings"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+)
+
+// TriggerStep is a single "Ctrl+Shift+V"-style combo: the modifier virtual keys that must be
+// held down plus either the virtual key that completes it, or, for a mouse gesture like
+// "Shift+RClick", the hook/windows mouse event Button it completes with instead
+type TriggerStep struct {
+	Modifiers   []uint16
+	Key         uint16
+	MouseButton string
+}
+
+// Trigger is a parsed shortcut trigger: a chord of one or more comma-separated steps, e.g.
+// "Ctrl+K, Ctrl+D" that must be typed one after another within the sequencer's timeout
+type Trigger struct {
+	Steps []TriggerStep
+}
+
+// IsMouse reports whether trigger fires on a mouse gesture rather than a keyboard sequence
+func (t Trigger) IsMouse() bool {
+	return len(t.Steps) == 1 && t.Steps[0].MouseButton != ""
+}
+
+// mouseButtonNameToEvent maps the mouse gesture names accepted in a shortcuts config to the
+// Button value hook/windows's mouse hook reports for the corresponding button-down event
+var mouseButtonNameToEvent = map[string]string{
+	"lclick":        "LButtonDown",
+	"rclick":        "RButtonDown",
+	"mclick":        "MButtonDown",
+	"xbutton1click": "XButton1Down",
+	"xbutton2click": "XButton2Down",
+}
+
+// keyNameToVK maps the key names accepted in a shortcuts config to their Win32 virtual key code,
+// mirroring the VK_* constants in hook/windows
+var keyNameToVK = map[string]uint16{
+	"ctrl":     win.VK_CONTROL,
+	"control":  win.VK_CONTROL,
+	"shift":    win.VK_SHIFT,
+	"alt":      win.VK_MENU,
+	"win":      win.VK_LWIN,
+	"back":     win.VK_BACK,
+	"tab":      win.VK_TAB,
+	"enter":    win.VK_RETURN,
+	"return":   win.VK_RETURN,
+	"esc":      win.VK_ESCAPE,
+	"escape":   win.VK_ESCAPE,
+	"space":    win.VK_SPACE,
+	"pageup":   win.VK_PRIOR,
+	"pagedown": win.VK_NEXT,
+	"end":      win.VK_END,
+	"home":     win.VK_HOME,
+	"left":     win.VK_LEFT,
+	"up":       win.VK_UP,
+	"right":    win.VK_RIGHT,
+	"down":     win.VK_DOWN,
+	"insert":   win.VK_INSERT,
+	"delete":   win.VK_DELETE,
+	"capital":  win.VK_CAPITAL,
+	"capslock": win.VK_CAPITAL,
+	"f1":       win.VK_F1,
+	"f2":       win.VK_F2,
+	"f3":       win.VK_F3,
+	"f4":       win.VK_F4,
+	"f5":       win.VK_F5,
+	"f6":       win.VK_F6,
+	"f7":       win.VK_F7,
+	"f8":       win.VK_F8,
+	"f9":       win.VK_F9,
+	"f10":      win.VK_F10,
+	"f11":      win.VK_F11,
+	"f12":      win.VK_F12,
+}
+
+func init() {
+	// 'A'-'Z' and '0'-'9' are their own virtual key codes on Windows
+	for c := 'A'; c <= 'Z'; c++ {
+		keyNameToVK[strings.ToLower(string(c))] = uint16(c)
+	}
+	for c := '0'; c <= '9'; c++ {
+		keyNameToVK[string(c)] = uint16(c)
+	}
+}
+
+// vkFromName resolves a key name (case-insensitive) to its virtual key code
+func vkFromName(name string) (uint16, error) {
+	vk, ok := keyNameToVK[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("unknown virtual key %q", name)
+	}
+
+	return vk, nil
+}
+
+// isModifierVK reports whether vk is one of Ctrl/Shift/Alt/Win
+func isModifierVK(vk uint16) bool {
+	switch vk {
+	case win.VK_CONTROL, win.VK_SHIFT, win.VK_MENU, win.VK_LWIN, win.VK_RWIN:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseTriggerStep parses a single "Ctrl+Shift+V"-style combo string, or a mouse gesture like
+// "Shift+RClick", into a TriggerStep, rejecting unknown virtual keys and mouse gesture names
+func ParseTriggerStep(s string) (TriggerStep, error) {
+	parts := strings.Split(s, "+")
+
+	step := TriggerStep{}
+	for i, part := range parts {
+		if i == len(parts)-1 {
+			if button, ok := mouseButtonNameToEvent[strings.ToLower(strings.TrimSpace(part))]; ok {
+				step.MouseButton = button
+				continue
+			}
+		}
+
+		vk, err := vkFromName(part)
+		if err != nil {
+			return TriggerStep{}, fmt.Errorf("combo %q: %w", s, err)
+		}
+
+		if i == len(parts)-1 && !isModifierVK(vk) {
+			step.Key = vk
+		} else {
+			step.Modifiers = append(step.Modifiers, vk)
+		}
+	}
+
+	if step.Key == 0 && step.MouseButton == "" {
+		return TriggerStep{}, fmt.Errorf("combo %q: missing non-modifier key or mouse gesture", s)
+	}
+
+	return step, nil
+}
+
+// ParseTrigger parses a trigger string - one or more comma-separated "Ctrl+Shift+V"-style combos,
+// e.g. "Ctrl+K, Ctrl+D", or a single mouse gesture like "Shift+RClick" - into a Trigger, rejecting
+// unknown virtual keys and mouse gestures chained into a multi-step sequence
+func ParseTrigger(s string) (Trigger, error) {
+	stepStrs := strings.Split(s, ",")
+
+	steps := make([]TriggerStep, 0, len(stepStrs))
+	for _, stepStr := range stepStrs {
+		step, err := ParseTriggerStep(strings.TrimSpace(stepStr))
+		if err != nil {
+			return Trigger{}, fmt.Errorf("trigger %q: %w", s, err)
+		}
+
+		steps = append(steps, step)
+	}
+
+	if len(steps) > 1 {
+		for _, step := range steps {
+			if step.MouseButton != "" {
+				return Trigger{}, fmt.Errorf("trigger %q: a mouse gesture can't be chained into a sequence", s)
+			}
+		}
+	}
+
+	return Trigger{Steps: steps}, nil
+}
@@ -0,0 +1,79 @@
+//go:build windows
+
+package shortcut
+
+import (
+	"testing"
+
+	win "github.com/ttimt/Short_Cutteer/hook/windows"
+)
+
+func TestParseTriggerStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    TriggerStep
+		wantErr bool
+	}{
+		{name: "single key", in: "K", want: TriggerStep{Key: 'K'}},
+		{name: "combo", in: "Ctrl+Shift+V", want: TriggerStep{Modifiers: []uint16{win.VK_CONTROL, win.VK_SHIFT}, Key: 'V'}},
+		{name: "mouse gesture", in: "Shift+RClick", want: TriggerStep{Modifiers: []uint16{win.VK_SHIFT}, MouseButton: "RButtonDown"}},
+		{name: "bare mouse gesture", in: "LClick", want: TriggerStep{MouseButton: "LButtonDown"}},
+		{name: "unknown key", in: "Ctrl+Nope", wantErr: true},
+		{name: "modifiers only", in: "Ctrl+Shift", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTriggerStep(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTriggerStep(%q) = %+v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTriggerStep(%q) unexpected error: %v", tt.in, err)
+			}
+			if got.Key != tt.want.Key || got.MouseButton != tt.want.MouseButton || len(got.Modifiers) != len(tt.want.Modifiers) {
+				t.Fatalf("ParseTriggerStep(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			for i, m := range tt.want.Modifiers {
+				if got.Modifiers[i] != m {
+					t.Fatalf("ParseTriggerStep(%q) modifiers = %v, want %v", tt.in, got.Modifiers, tt.want.Modifiers)
+				}
+			}
+		})
+	}
+}
+
+func TestParseTrigger(t *testing.T) {
+	t.Run("chord", func(t *testing.T) {
+		trigger, err := ParseTrigger("Ctrl+K, Ctrl+D")
+		if err != nil {
+			t.Fatalf("ParseTrigger: unexpected error: %v", err)
+		}
+		if len(trigger.Steps) != 2 {
+			t.Fatalf("ParseTrigger: got %d steps, want 2", len(trigger.Steps))
+		}
+		if trigger.IsMouse() {
+			t.Fatal("ParseTrigger: chord reported as IsMouse")
+		}
+	})
+
+	t.Run("mouse gesture", func(t *testing.T) {
+		trigger, err := ParseTrigger("Shift+RClick")
+		if err != nil {
+			t.Fatalf("ParseTrigger: unexpected error: %v", err)
+		}
+		if !trigger.IsMouse() {
+			t.Fatal("ParseTrigger: mouse gesture not reported as IsMouse")
+		}
+	})
+
+	t.Run("mouse gesture cannot be chained", func(t *testing.T) {
+		if _, err := ParseTrigger("Ctrl+K, RClick"); err == nil {
+			t.Fatal("ParseTrigger: expected error chaining a mouse gesture into a sequence")
+		}
+	})
+}
@@ -0,0 +1,52 @@
+//go:build windows
+
+package shortcut
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// WatchConfig reloads engine's shortcuts whenever path is written to. A bad edit only logs a
+// reload error; the engine keeps running with the shortcuts it already has.
+func WatchConfig(path string, engine *Engine) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go watchLoop(watcher, path, engine)
+
+	return watcher, nil
+}
+
+// watchLoop reloads path into engine on every write/create event for it, until watcher is closed
+func watchLoop(watcher *fsnotify.Watcher, path string, engine *Engine) {
+	cleanPath := filepath.Clean(path)
+
+	for event := range watcher.Events {
+		if filepath.Clean(event.Name) != cleanPath || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+			continue
+		}
+
+		config, err := LoadConfig(path)
+		if err != nil {
+			log.Println("shortcuts config reload failed:", err)
+			continue
+		}
+
+		if err := engine.Load(config); err != nil {
+			log.Println("shortcuts config reload failed:", err)
+			continue
+		}
+
+		log.Println("shortcuts config reloaded:", path)
+	}
+}